@@ -0,0 +1,81 @@
+// Package core hosts cross-pool infrastructure shared by the transaction
+// pool's subpools, starting with concurrent sender recovery.
+package core
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/txpool/core/types"
+)
+
+// txSenderCacherBatch is the number of transactions handed to a single worker
+// per task, balancing per-task overhead against keeping all workers busy.
+const txSenderCacherBatch = 200
+
+// SenderCacher is the package-wide sender recovery cache. AddTx and batch
+// ingress paths call Recover on it before taking the pool lock, so the
+// secp256k1 work for a whole batch happens in parallel off the hot path;
+// types.Sender itself memoizes the result on the transaction, so the
+// subsequent lookup inside the pool is free.
+var SenderCacher = newTxSenderCacher(runtime.NumCPU())
+
+// senderCacherRequest asks a worker to recover the senders of txs using
+// signer, signaling done once it has.
+type senderCacherRequest struct {
+	signer types.Signer
+	txs    []*types.Transaction
+	done   *sync.WaitGroup
+}
+
+// txSenderCacher runs a fixed pool of workers recovering transaction senders
+// in parallel.
+type txSenderCacher struct {
+	tasks chan *senderCacherRequest
+}
+
+// newTxSenderCacher starts a cacher with the given number of workers.
+func newTxSenderCacher(workers int) *txSenderCacher {
+	if workers < 1 {
+		workers = 1
+	}
+	cacher := &txSenderCacher{
+		tasks: make(chan *senderCacherRequest, workers),
+	}
+	for i := 0; i < workers; i++ {
+		go cacher.cache()
+	}
+	return cacher
+}
+
+// cache services recovery requests until the process exits.
+func (cacher *txSenderCacher) cache() {
+	for task := range cacher.tasks {
+		for _, tx := range task.txs {
+			types.Sender(task.signer, tx)
+		}
+		task.done.Done()
+	}
+}
+
+// Recover fans the given transactions out across the cacher's workers,
+// memoizing each transaction's sender via types.Sender's own cache, and
+// blocks until every batch has been recovered. Errors are ignored here; any
+// transaction whose sender can't be recovered will simply fail again,
+// synchronously, at insertion time.
+func (cacher *txSenderCacher) Recover(signer types.Signer, txs []*types.Transaction) {
+	if len(txs) == 0 {
+		return
+	}
+	var wg sync.WaitGroup
+	for len(txs) > 0 {
+		batch := txSenderCacherBatch
+		if batch > len(txs) {
+			batch = len(txs)
+		}
+		wg.Add(1)
+		cacher.tasks <- &senderCacherRequest{signer: signer, txs: txs[:batch], done: &wg}
+		txs = txs[batch:]
+	}
+	wg.Wait()
+}
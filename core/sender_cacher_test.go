@@ -0,0 +1,58 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/txpool/common"
+	"github.com/txpool/core/types"
+)
+
+// makeBenchTxs returns n signed transactions, all from the same key, for use
+// as sender-recovery benchmark input.
+func makeBenchTxs(b *testing.B, signer types.Signer, n int) []*types.Transaction {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		b.Fatal(err)
+	}
+	txs := make([]*types.Transaction, n)
+	for i := 0; i < n; i++ {
+		tx, err := types.SignTx(types.NewTransaction(uint64(i), common.Address{}, nil, 0, nil, nil), signer, key)
+		if err != nil {
+			b.Fatal(err)
+		}
+		txs[i] = tx
+	}
+	return txs
+}
+
+// BenchmarkSenderCacherRecover compares recovering senders one at a time
+// against warming them concurrently through SenderCacher first. types.Sender
+// memoizes its result on the transaction, so each measured iteration builds
+// its own fresh, never-recovered batch; reusing one batch across iterations
+// would make every pass after the first a no-op cache hit on both sides.
+func BenchmarkSenderCacherRecover(b *testing.B) {
+	signer := types.HomesteadSigner{}
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			txs := makeBenchTxs(b, signer, 1024)
+			b.StartTimer()
+
+			for _, tx := range txs {
+				types.Sender(signer, tx)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			txs := makeBenchTxs(b, signer, 1024)
+			b.StartTimer()
+
+			SenderCacher.Recover(signer, txs)
+		}
+	})
+}
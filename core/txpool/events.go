@@ -0,0 +1,13 @@
+package txpool
+
+import "github.com/txpool/core/types"
+
+// NewTxsEvent is posted when a batch of transactions has been accepted into
+// the pool, so block producers and RPC layers can react without polling
+// GetTxs.
+type NewTxsEvent struct{ Txs []*types.Transaction }
+
+// DropTxsEvent is posted when a batch of transactions has left the pool,
+// whether committed via DelTxs or evicted internally by a subpool, so
+// downstream consumers can invalidate any caches built on pool contents.
+type DropTxsEvent struct{ Txs []*types.Transaction }
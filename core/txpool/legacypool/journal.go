@@ -0,0 +1,161 @@
+package legacypool
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/txpool/common"
+	"github.com/txpool/common/log"
+	"github.com/txpool/core/types"
+)
+
+// errNoActiveJournal is returned if a transaction is attempted to be inserted
+// into the journal, but no such file is currently open.
+var errNoActiveJournal = errors.New("no active journal")
+
+// accountSet tracks a set of addresses with O(1) membership checks, used here
+// to remember which senders are "local" and therefore journaled and exempt
+// from eviction.
+type accountSet struct {
+	accounts map[common.Address]struct{}
+}
+
+// newAccountSet creates a new, empty account set.
+func newAccountSet() *accountSet {
+	return &accountSet{
+		accounts: make(map[common.Address]struct{}),
+	}
+}
+
+// add inserts an address into the set.
+func (as *accountSet) add(addr common.Address) {
+	as.accounts[addr] = struct{}{}
+}
+
+// contains reports whether an address is tracked by the set.
+func (as *accountSet) contains(addr common.Address) bool {
+	_, exists := as.accounts[addr]
+	return exists
+}
+
+// discard is an io.WriteCloser that drops everything written to it.
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+func (discard) Close() error                { return nil }
+
+// txJournal is a rotating on-disk log of locally submitted transactions, kept
+// so they can be replayed back into the pool after a node restart.
+type txJournal struct {
+	path   string         // Filesystem path to store the transactions at
+	writer io.WriteCloser // Output stream to append newly submitted transactions to
+}
+
+// newTxJournal creates a journal bound to the given path. The file itself is
+// opened lazily, the first time the journal is loaded or rotated.
+func newTxJournal(path string) *txJournal {
+	return &txJournal{
+		path: path,
+	}
+}
+
+// load parses a transaction journal dump from disk, handing every decoded
+// transaction to add for re-insertion into the pool.
+func (journal *txJournal) load(add func(*types.Transaction) error) error {
+	if _, err := os.Stat(journal.path); os.IsNotExist(err) {
+		return nil
+	}
+	input, err := os.Open(journal.path)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+
+	// Transactions replayed during load are re-inserted through the normal
+	// "local" path, which would otherwise try to journal them right back;
+	// point the writer at a sink for the duration of the load to avoid that.
+	journal.writer = discard{}
+	defer func() { journal.writer = nil }()
+
+	stream := rlp.NewStream(input, 0)
+	total, dropped := 0, 0
+
+	var failure error
+	for {
+		tx := new(types.Transaction)
+		if err = stream.Decode(tx); err != nil {
+			if err != io.EOF {
+				failure = err
+			}
+			break
+		}
+		total++
+		if err := add(tx); err != nil {
+			log.Warn("Failed to add journaled transaction.")
+			dropped++
+		}
+	}
+	log.Info("Loaded local transaction journal.")
+
+	return failure
+}
+
+// insert appends a single transaction to the journal file.
+func (journal *txJournal) insert(tx *types.Transaction) error {
+	if journal.writer == nil {
+		return errNoActiveJournal
+	}
+	return rlp.Encode(journal.writer, tx)
+}
+
+// rotate regenerates the journal from the given per-account transactions,
+// writing to a temporary file and renaming it into place so a crash mid-write
+// never leaves a torn journal behind.
+func (journal *txJournal) rotate(all map[common.Address][]*types.Transaction) error {
+	if journal.writer != nil {
+		if err := journal.writer.Close(); err != nil {
+			return err
+		}
+		journal.writer = nil
+	}
+
+	replacement, err := os.OpenFile(journal.path+".new", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	journaled := 0
+	for _, txs := range all {
+		for _, tx := range txs {
+			if err := rlp.Encode(replacement, tx); err != nil {
+				replacement.Close()
+				return err
+			}
+		}
+		journaled += len(txs)
+	}
+	replacement.Close()
+
+	if err := os.Rename(journal.path+".new", journal.path); err != nil {
+		return err
+	}
+	sink, err := os.OpenFile(journal.path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	journal.writer = sink
+	log.Info("Regenerated local transaction journal.")
+
+	return nil
+}
+
+// close flushes and closes the journal file, if open.
+func (journal *txJournal) close() error {
+	if journal.writer == nil {
+		return nil
+	}
+	err := journal.writer.Close()
+	journal.writer = nil
+	return err
+}
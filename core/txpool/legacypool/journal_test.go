@@ -0,0 +1,46 @@
+package legacypool
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/txpool/common"
+	"github.com/txpool/core/types"
+)
+
+// TestTxJournalRoundTrip checks that a local transaction survives a rotate
+// followed by a fresh load against the same path.
+func TestTxJournalRoundTrip(t *testing.T) {
+	signer := types.HomesteadSigner{}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := crypto.PubkeyToAddress(key.PublicKey)
+
+	tx := signedTx(t, signer, key, 0, 1)
+
+	path := filepath.Join(t.TempDir(), "transactions.rlp")
+	journal := newTxJournal(path)
+
+	if err := journal.rotate(map[common.Address][]*types.Transaction{from: {tx}}); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if err := journal.close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	reloaded := newTxJournal(path)
+	var got []*types.Transaction
+	if err := reloaded.load(func(tx *types.Transaction) error {
+		got = append(got, tx)
+		return nil
+	}); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	if len(got) != 1 || got[0].Hash() != tx.Hash() {
+		t.Fatalf("load() = %v, want the single journaled transaction back", got)
+	}
+}
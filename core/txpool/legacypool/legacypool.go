@@ -0,0 +1,624 @@
+// Package legacypool implements the original map-backed transaction pool as a
+// txpool.SubPool, handling the current types.Transaction kind.
+package legacypool
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/txpool/common"
+	"github.com/txpool/common/log"
+	"github.com/txpool/core"
+	"github.com/txpool/core/txpool"
+	"github.com/txpool/core/types"
+)
+
+// define errors description for error.
+var (
+	ErrDuplicateTxs          = errors.New("duplicate transaction")
+	ErrAccountTxsPoolHasFull = errors.New("pending of the account has full now")
+	ErrUnderpriced           = errors.New("transaction underpriced")
+	ErrReplaceUnderpriced    = errors.New("replacement transaction underpriced")
+	ErrTxPoolOverflow        = errors.New("txpool is full and no transaction could be evicted to make room")
+)
+
+// maxIncludedTxs bounds how many committed transaction hashes LegacyPool
+// remembers for Status to report TxStatusIncluded on, so the set can't grow
+// without limit as blocks are mined.
+const maxIncludedTxs = 4096
+
+// DefaultConfig contains the default configurations for the legacy pool.
+var DefaultConfig = Config{
+	GlobalSlots: 4096,
+	PriceLimit:  1,
+	PriceBump:   10,
+	Rejournal:   time.Hour,
+}
+
+// Config are the configuration parameters of the legacy pool.
+type Config struct {
+	GlobalSlots uint64       // Maximum number of transaction slots for the pool, pending and queued together
+	Signer      types.Signer // Signer used to recover the sender of a transaction
+
+	PriceLimit uint64 // Minimum gas price to enforce for acceptance into the pool
+	PriceBump  uint64 // Minimum percentage price bump to replace an already existing transaction
+
+	Journal   string        // Disk journal for local transaction to survive node restarts
+	Rejournal time.Duration // Time interval to regenerate the local transaction journal
+
+	AccountIdleTime time.Duration // Maximum time a non-local account's queued transactions sit idle before eviction; 0 disables idle eviction
+}
+
+// sanitize checks the provided user configurations and changes anything that's  unreasonable or unworkable.
+func (config *Config) sanitize() Config {
+	conf := *config
+	if conf.GlobalSlots < 1 {
+		log.Warn("Sanitizing invalid txpool global slots.")
+		conf.GlobalSlots = DefaultConfig.GlobalSlots
+	}
+	if conf.PriceLimit < 1 {
+		log.Warn("Sanitizing invalid txpool price limit.")
+		conf.PriceLimit = DefaultConfig.PriceLimit
+	}
+	if conf.PriceBump < 1 {
+		log.Warn("Sanitizing invalid txpool price bump.")
+		conf.PriceBump = DefaultConfig.PriceBump
+	}
+	if conf.Rejournal < 1 {
+		log.Warn("Sanitizing invalid txpool journal time.")
+		conf.Rejournal = DefaultConfig.Rejournal
+	}
+	return conf
+}
+
+// structure for tx lookup.
+type txLookup struct {
+	all map[common.Hash]*types.Transaction
+}
+
+// newTxLookup returns a new txLookup structure.
+func newTxLookup() *txLookup {
+	return &txLookup{
+		all: make(map[common.Hash]*types.Transaction),
+	}
+}
+
+// Get returns a transaction if it exists in the lookup, or nil if not found.
+func (t *txLookup) Get(hash common.Hash) *types.Transaction {
+	return t.all[hash]
+}
+
+// Count returns the current number of items in the lookup.
+func (t *txLookup) Count() int {
+	return len(t.all)
+}
+
+// Add adds a transaction to the lookup.
+func (t *txLookup) Add(tx *types.Transaction) {
+	t.all[tx.Hash()] = tx
+}
+
+// Remove removes a transaction from the lookup.
+func (t *txLookup) Remove(hash common.Hash) {
+	delete(t.all, hash)
+}
+
+// LegacyPool is a txpool.SubPool handling the current types.Transaction kind.
+// Besides the flat all index, transactions are tracked per account as either
+// pending (nonce-contiguous, executable) or queued (blocked on a nonce gap).
+// All fields below mu are guarded by it; every exported method takes the lock
+// itself, and unexported helpers assume it is already held by the caller.
+type LegacyPool struct {
+	config Config
+
+	mu sync.RWMutex
+
+	all     *txLookup
+	priced  *txPricedList                // All known transactions, ordered by gas price, for eviction
+	pending map[common.Address]*txList   // Nonce-contiguous, executable transactions
+	queue   map[common.Address]*txList   // Non-executable transactions waiting on a nonce gap
+	beats   map[common.Address]time.Time // Last activity per account, used to evict idle accounts
+
+	locals  *accountSet // Senders whose transactions are journaled and exempt from eviction
+	journal *txJournal  // Disk journal replaying local transactions across restarts
+
+	dropped []*types.Transaction // Transactions evicted since the last Drained call
+
+	included      map[common.Hash]struct{} // Recently committed transaction hashes, for Status
+	includedOrder []common.Hash            // FIFO order backing included, bounded by maxIncludedTxs
+
+	quit chan struct{}
+	wg   sync.WaitGroup
+}
+
+// New creates a new legacy pool to gather, sort and filter inbound transactions from the network and local.
+func New(config Config) *LegacyPool {
+	config = (&config).sanitize()
+
+	all := newTxLookup()
+
+	// Create the transaction pool with its initial settings
+	pool := &LegacyPool{
+		config:   config,
+		all:      all,
+		priced:   newTxPricedList(all),
+		pending:  make(map[common.Address]*txList),
+		queue:    make(map[common.Address]*txList),
+		beats:    make(map[common.Address]time.Time),
+		locals:   newAccountSet(),
+		included: make(map[common.Hash]struct{}),
+		quit:     make(chan struct{}),
+	}
+
+	// If a journal path is configured, load its contents into the pool and
+	// start the background rotation that keeps it up to date.
+	if config.Journal != "" {
+		pool.journal = newTxJournal(config.Journal)
+
+		if err := pool.journal.load(func(tx *types.Transaction) error {
+			return pool.addTx(tx, true)
+		}); err != nil {
+			log.Warn("Failed to load transaction journal.")
+		}
+		if err := pool.journal.rotate(pool.localTxs()); err != nil {
+			log.Warn("Failed to rotate transaction journal.")
+		}
+
+		pool.wg.Add(1)
+		go pool.journalLoop()
+	}
+
+	return pool
+}
+
+// Stop terminates the background journal rotation goroutine, if any, and
+// flushes the journal to disk.
+func (pool *LegacyPool) Stop() {
+	close(pool.quit)
+	pool.wg.Wait()
+
+	if pool.journal != nil {
+		pool.journal.close()
+	}
+}
+
+// journalLoop periodically regenerates the local transaction journal so it
+// only ever holds transactions still resident in the pool.
+func (pool *LegacyPool) journalLoop() {
+	defer pool.wg.Done()
+
+	ticker := time.NewTicker(pool.config.Rejournal)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			pool.mu.Lock()
+			if err := pool.journal.rotate(pool.localTxs()); err != nil {
+				log.Warn("Failed to rotate transaction journal.")
+			}
+			pool.mu.Unlock()
+		case <-pool.quit:
+			return
+		}
+	}
+}
+
+// localTxs collects the transactions belonging to local accounts, across both
+// the pending and queued sets, for the journal to persist. Callers must hold
+// pool.mu.
+func (pool *LegacyPool) localTxs() map[common.Address][]*types.Transaction {
+	txs := make(map[common.Address][]*types.Transaction)
+	for addr := range pool.locals.accounts {
+		var list []*types.Transaction
+		if plist := pool.pending[addr]; plist != nil {
+			list = append(list, plist.Flatten()...)
+		}
+		if qlist := pool.queue[addr]; qlist != nil {
+			list = append(list, qlist.Flatten()...)
+		}
+		if len(list) > 0 {
+			txs[addr] = list
+		}
+	}
+	return txs
+}
+
+// Filter reports whether the transaction is handled by the legacy pool. The
+// legacy pool is the catch-all for types.Transaction, so it always accepts.
+func (pool *LegacyPool) Filter(tx *types.Transaction) bool {
+	return true
+}
+
+// Add adds a transaction received from the network to the legacy pool,
+// placing it in the queue if it opens a nonce gap for its sender, or directly
+// in pending (and promoting any now-contiguous queued transactions) otherwise.
+func (pool *LegacyPool) Add(tx *types.Transaction) error {
+	core.SenderCacher.Recover(pool.config.Signer, []*types.Transaction{tx})
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	return pool.addTx(tx, false)
+}
+
+// AddTxs adds a batch of network-received transactions, warming the sender
+// cache across the whole batch concurrently before inserting them one by
+// one, so the secp256k1 recovery work happens off the per-transaction
+// insertion path.
+func (pool *LegacyPool) AddTxs(txs []*types.Transaction) []error {
+	core.SenderCacher.Recover(pool.config.Signer, txs)
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		errs[i] = pool.addTx(tx, false)
+	}
+	return errs
+}
+
+// AddLocal adds a transaction submitted by the local node. Local transactions
+// are journaled to disk so they survive a restart and are exempt from
+// eviction rules.
+func (pool *LegacyPool) AddLocal(tx *types.Transaction) error {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	return pool.addTx(tx, true)
+}
+
+// addTx is the shared implementation behind Add and AddLocal. Callers must
+// hold pool.mu for writing.
+func (pool *LegacyPool) addTx(tx *types.Transaction, local bool) error {
+	if nil != pool.all.Get(tx.Hash()) {
+		log.Error("The tx has exist, please confirm.")
+		return ErrDuplicateTxs
+	}
+	if tx.GasPrice().Uint64() < pool.config.PriceLimit {
+		return ErrUnderpriced
+	}
+
+	from, err := types.Sender(pool.config.Signer, tx)
+	if err != nil {
+		return err
+	}
+
+	plist := pool.pending[from]
+	if plist == nil {
+		plist = newTxList()
+		pool.pending[from] = plist
+	}
+	qlist := pool.queue[from]
+
+	// A transaction already occupying this nonce is only displaced by a
+	// sufficiently fee-bumped newcomer.
+	old := plist.Get(tx.Nonce())
+	if old == nil && qlist != nil {
+		old = qlist.Get(tx.Nonce())
+	}
+	if old != nil {
+		if !priceBump(old, tx, pool.config.PriceBump) {
+			return ErrReplaceUnderpriced
+		}
+		pool.all.Remove(old.Hash())
+		pool.priced.Removed()
+		pool.dropped = append(pool.dropped, old)
+	} else if uint64(pool.all.Count()) >= pool.config.GlobalSlots {
+		// The pool is full and this transaction would need a new slot.
+		// First reclaim room from accounts that have gone idle, then fall
+		// back to price-based eviction, unless the newcomer itself is the
+		// cheapest of the lot.
+		pool.evictIdleAccounts()
+		if uint64(pool.all.Count()) >= pool.config.GlobalSlots {
+			if pool.priced.Underpriced(tx) {
+				return ErrUnderpriced
+			}
+			need := pool.all.Count() - int(pool.config.GlobalSlots) + 1
+			drop, ok := pool.priced.Discard(need, pool.isLocalTx)
+			for _, tx := range drop {
+				pool.evict(tx.Hash())
+			}
+			if !ok {
+				// Every remaining candidate was exempt from eviction (e.g.
+				// all local); refuse the newcomer rather than growing past
+				// GlobalSlots.
+				return ErrTxPoolOverflow
+			}
+		}
+	}
+
+	pool.all.Add(tx)
+	pool.priced.Put(tx)
+	pool.beats[from] = time.Now()
+
+	if local {
+		pool.locals.add(from)
+		if pool.journal != nil {
+			if err := pool.journal.insert(tx); err != nil {
+				log.Warn("Failed to journal local transaction.")
+			}
+		}
+	}
+
+	if old != nil {
+		// Replacement: stay in whichever list the displaced transaction occupied.
+		if plist.Get(tx.Nonce()) == old {
+			plist.Put(tx)
+		} else {
+			qlist.Put(tx)
+		}
+		return nil
+	}
+
+	// An account's nonces are taken to start at zero, as in promoteQueued; a
+	// transaction is executable only if it closes the gap behind it.
+	expected := uint64(0)
+	if plist.Len() > 0 {
+		flat := plist.Flatten()
+		expected = flat[len(flat)-1].Nonce() + 1
+	}
+
+	if tx.Nonce() == expected {
+		plist.Put(tx)
+		pool.promoteQueued(from)
+	} else {
+		if qlist == nil {
+			qlist = newTxList()
+			pool.queue[from] = qlist
+		}
+		qlist.Put(tx)
+	}
+	return nil
+}
+
+// evictIdleAccounts purges the queued (non-executable) transactions of
+// non-local accounts that haven't seen activity within AccountIdleTime,
+// reclaiming slots before falling back to price-based eviction. Callers must
+// hold pool.mu.
+func (pool *LegacyPool) evictIdleAccounts() {
+	if pool.config.AccountIdleTime <= 0 {
+		return
+	}
+	now := time.Now()
+	for addr, beat := range pool.beats {
+		if pool.locals.contains(addr) {
+			continue
+		}
+		if now.Sub(beat) < pool.config.AccountIdleTime {
+			continue
+		}
+		qlist := pool.queue[addr]
+		if qlist == nil {
+			continue
+		}
+		for _, tx := range qlist.Flatten() {
+			pool.evict(tx.Hash())
+		}
+	}
+}
+
+// isLocalTx reports whether tx's sender is tracked as a local account, used
+// to exempt local transactions from price-based eviction. Callers must hold
+// pool.mu.
+func (pool *LegacyPool) isLocalTx(tx *types.Transaction) bool {
+	from, err := types.Sender(pool.config.Signer, tx)
+	return err == nil && pool.locals.contains(from)
+}
+
+// promoteQueued moves queued transactions for an account into pending for as
+// long as the queue holds the next contiguous nonce. Callers must hold pool.mu.
+func (pool *LegacyPool) promoteQueued(from common.Address) {
+	qlist := pool.queue[from]
+	if qlist == nil {
+		return
+	}
+	plist := pool.pending[from]
+	for {
+		next := uint64(0)
+		if plist.Len() > 0 {
+			flat := plist.Flatten()
+			next = flat[len(flat)-1].Nonce() + 1
+		}
+		tx := qlist.Get(next)
+		if tx == nil {
+			break
+		}
+		plist.Put(tx)
+		qlist.Remove(next)
+	}
+	if qlist.Len() == 0 {
+		delete(pool.queue, from)
+	}
+}
+
+// Pending returns the executable transactions held by the legacy pool.
+func (pool *LegacyPool) Pending() []*types.Transaction {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	txs := make([]*types.Transaction, 0, pool.all.Count())
+	for _, list := range pool.pending {
+		txs = append(txs, list.Flatten()...)
+	}
+	return txs
+}
+
+// Get returns a transaction if it exists in the pool, or nil if not found.
+func (pool *LegacyPool) Get(hash common.Hash) *types.Transaction {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	return pool.all.Get(hash)
+}
+
+// Remove removes a transaction from the pool, demoting it out of whichever of
+// pending or queue held it.
+func (pool *LegacyPool) Remove(hash common.Hash) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.evict(hash)
+}
+
+// Include removes a transaction from the pool because it was committed to the
+// chain, rather than dropped by pool policy: it is not recorded into dropped,
+// so it won't surface in a DropTxsEvent, but its hash is remembered so Status
+// can report TxStatusIncluded for it afterwards.
+func (pool *LegacyPool) Include(hash common.Hash) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if pool.remove(hash) == nil {
+		return
+	}
+	pool.markIncluded(hash)
+}
+
+// markIncluded records hash as committed, evicting the oldest recorded hash
+// once the FIFO grows past maxIncludedTxs. Callers must hold pool.mu.
+func (pool *LegacyPool) markIncluded(hash common.Hash) {
+	if _, ok := pool.included[hash]; ok {
+		return
+	}
+	pool.included[hash] = struct{}{}
+	pool.includedOrder = append(pool.includedOrder, hash)
+
+	if len(pool.includedOrder) > maxIncludedTxs {
+		oldest := pool.includedOrder[0]
+		pool.includedOrder = pool.includedOrder[1:]
+		delete(pool.included, oldest)
+	}
+}
+
+// evict removes a single transaction from all, priced and the pending/queue
+// lists of its sender, recording it as dropped. Callers must hold pool.mu.
+func (pool *LegacyPool) evict(hash common.Hash) {
+	tx := pool.remove(hash)
+	if tx != nil {
+		pool.dropped = append(pool.dropped, tx)
+	}
+}
+
+// remove removes a single transaction from all, priced and the pending/queue
+// lists of its sender, returning the removed transaction, or nil if the pool
+// didn't have it. Callers must hold pool.mu.
+func (pool *LegacyPool) remove(hash common.Hash) *types.Transaction {
+	tx := pool.all.Get(hash)
+	if tx == nil {
+		return nil
+	}
+	from, err := types.Sender(pool.config.Signer, tx)
+	if err != nil {
+		return nil
+	}
+	pool.all.Remove(hash)
+	pool.priced.Removed()
+
+	if plist := pool.pending[from]; plist != nil && plist.Get(tx.Nonce()) != nil {
+		plist.Remove(tx.Nonce())
+		if plist.Len() == 0 {
+			delete(pool.pending, from)
+		} else {
+			// Evicting tx out of the middle of a contiguous run opens a gap
+			// behind every higher-nonce transaction still in pending; they're
+			// no longer executable, so demote them back to queue.
+			pool.demoteGap(from, tx.Nonce())
+		}
+	} else if qlist := pool.queue[from]; qlist != nil {
+		qlist.Remove(tx.Nonce())
+		if qlist.Len() == 0 {
+			delete(pool.queue, from)
+		}
+	}
+
+	if pool.pending[from] == nil && pool.queue[from] == nil {
+		delete(pool.beats, from)
+	}
+	return tx
+}
+
+// demoteGap moves every pending transaction of from with a nonce above gap
+// back into queue, since the removal of the transaction at gap has made them
+// non-contiguous. Callers must hold pool.mu.
+func (pool *LegacyPool) demoteGap(from common.Address, gap uint64) {
+	plist := pool.pending[from]
+	if plist == nil {
+		return
+	}
+	var orphaned []*types.Transaction
+	for _, tx := range plist.Flatten() {
+		if tx.Nonce() > gap {
+			orphaned = append(orphaned, tx)
+		}
+	}
+	if len(orphaned) == 0 {
+		return
+	}
+	qlist := pool.queue[from]
+	if qlist == nil {
+		qlist = newTxList()
+		pool.queue[from] = qlist
+	}
+	for _, tx := range orphaned {
+		plist.Remove(tx.Nonce())
+		qlist.Put(tx)
+	}
+	if plist.Len() == 0 {
+		delete(pool.pending, from)
+	}
+}
+
+// Drained returns and clears the transactions evicted from the pool since the
+// last call, whether removed via Remove or displaced by a fee-bumped
+// replacement, for the router to fan out as DropTxsEvents.
+func (pool *LegacyPool) Drained() []*types.Transaction {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	dropped := pool.dropped
+	pool.dropped = nil
+	return dropped
+}
+
+// Status returns the lifecycle status of a transaction known to the pool.
+func (pool *LegacyPool) Status(hash common.Hash) txpool.TxStatus {
+	pool.mu.RLock()
+	defer pool.mu.RUnlock()
+
+	if _, ok := pool.included[hash]; ok {
+		return txpool.TxStatusIncluded
+	}
+	tx := pool.all.Get(hash)
+	if tx == nil {
+		return txpool.TxStatusUnknown
+	}
+	from, err := types.Sender(pool.config.Signer, tx)
+	if err != nil {
+		return txpool.TxStatusUnknown
+	}
+	if plist := pool.pending[from]; plist != nil && plist.Get(tx.Nonce()) != nil {
+		return txpool.TxStatusPending
+	}
+	if qlist := pool.queue[from]; qlist != nil && qlist.Get(tx.Nonce()) != nil {
+		return txpool.TxStatusQueued
+	}
+	return txpool.TxStatusUnknown
+}
+
+// Reset clears the legacy pool, discarding all known transactions.
+func (pool *LegacyPool) Reset() {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pool.all = newTxLookup()
+	pool.priced = newTxPricedList(pool.all)
+	pool.pending = make(map[common.Address]*txList)
+	pool.queue = make(map[common.Address]*txList)
+	pool.beats = make(map[common.Address]time.Time)
+	pool.dropped = nil
+	pool.included = make(map[common.Hash]struct{})
+	pool.includedOrder = nil
+}
@@ -0,0 +1,156 @@
+package legacypool
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/txpool/common"
+	"github.com/txpool/core/txpool"
+	"github.com/txpool/core/types"
+)
+
+// signedTx returns a transaction signed by key, for use as pool test input.
+func signedTx(t *testing.T, signer types.Signer, key *ecdsa.PrivateKey, nonce uint64, gasPrice int64) *types.Transaction {
+	tx, err := types.SignTx(types.NewTransaction(nonce, common.Address{}, nil, 0, big.NewInt(gasPrice), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func TestLegacyPoolQueueThenPromote(t *testing.T) {
+	signer := types.HomesteadSigner{}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := New(Config{GlobalSlots: 10, PriceLimit: 1, PriceBump: 10, Signer: signer})
+
+	tx1 := signedTx(t, signer, key, 1, 1)
+	if err := pool.Add(tx1); err != nil {
+		t.Fatalf("unexpected error queuing tx1: %v", err)
+	}
+	if status := pool.Status(tx1.Hash()); status != txpool.TxStatusQueued {
+		t.Fatalf("tx1 status = %v, want TxStatusQueued", status)
+	}
+
+	tx0 := signedTx(t, signer, key, 0, 1)
+	if err := pool.Add(tx0); err != nil {
+		t.Fatalf("unexpected error adding tx0: %v", err)
+	}
+
+	if status := pool.Status(tx0.Hash()); status != txpool.TxStatusPending {
+		t.Fatalf("tx0 status = %v, want TxStatusPending", status)
+	}
+	if status := pool.Status(tx1.Hash()); status != txpool.TxStatusPending {
+		t.Fatalf("tx1 status = %v after promotion, want TxStatusPending", status)
+	}
+	if n := len(pool.Pending()); n != 2 {
+		t.Fatalf("len(Pending()) = %d, want 2", n)
+	}
+}
+
+func TestLegacyPoolPriceEviction(t *testing.T) {
+	signer := types.HomesteadSigner{}
+
+	pool := New(Config{GlobalSlots: 2, PriceLimit: 1, PriceBump: 10, Signer: signer})
+
+	var cheap *types.Transaction
+	for i := 0; i < 2; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := signedTx(t, signer, key, 0, int64(i+1))
+		if i == 0 {
+			cheap = tx
+		}
+		if err := pool.Add(tx); err != nil {
+			t.Fatalf("unexpected error adding tx %d: %v", i, err)
+		}
+	}
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expensive := signedTx(t, signer, key, 0, 100)
+	if err := pool.Add(expensive); err != nil {
+		t.Fatalf("unexpected error adding the expensive tx: %v", err)
+	}
+
+	if pool.Get(cheap.Hash()) != nil {
+		t.Fatal("cheapest transaction should have been evicted to make room")
+	}
+	if pool.Get(expensive.Hash()) == nil {
+		t.Fatal("expensive transaction should have been accepted")
+	}
+	if dropped := pool.Drained(); len(dropped) != 1 || dropped[0].Hash() != cheap.Hash() {
+		t.Fatalf("Drained() = %v, want the evicted cheap tx", dropped)
+	}
+}
+
+func TestLegacyPoolIdleEvictionAdmitsCheapTx(t *testing.T) {
+	signer := types.HomesteadSigner{}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := New(Config{GlobalSlots: 2, PriceLimit: 1, PriceBump: 10, Signer: signer, AccountIdleTime: time.Millisecond})
+
+	tx0 := signedTx(t, signer, key, 0, 5)
+	if err := pool.Add(tx0); err != nil {
+		t.Fatalf("unexpected error adding tx0: %v", err)
+	}
+	tx2 := signedTx(t, signer, key, 2, 5)
+	if err := pool.Add(tx2); err != nil {
+		t.Fatalf("unexpected error queuing tx2: %v", err)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+
+	cheapKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cheap := signedTx(t, signer, cheapKey, 0, 1)
+	if err := pool.Add(cheap); err != nil {
+		t.Fatalf("cheap tx should have been admitted once idle eviction freed room, got: %v", err)
+	}
+
+	if pool.Get(tx2.Hash()) != nil {
+		t.Fatal("the idle account's queued transaction should have been evicted")
+	}
+	if pool.Get(cheap.Hash()) == nil {
+		t.Fatal("the cheap transaction should have been admitted")
+	}
+}
+
+func TestLegacyPoolIncludeReportsStatus(t *testing.T) {
+	signer := types.HomesteadSigner{}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pool := New(Config{GlobalSlots: 10, PriceLimit: 1, PriceBump: 10, Signer: signer})
+
+	tx := signedTx(t, signer, key, 0, 1)
+	if err := pool.Add(tx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pool.Include(tx.Hash())
+
+	if status := pool.Status(tx.Hash()); status != txpool.TxStatusIncluded {
+		t.Fatalf("status after Include = %v, want TxStatusIncluded", status)
+	}
+	if dropped := pool.Drained(); len(dropped) != 0 {
+		t.Fatalf("Drained() = %v, Include should not surface as a drop", dropped)
+	}
+}
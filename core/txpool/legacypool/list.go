@@ -0,0 +1,56 @@
+package legacypool
+
+import (
+	"sort"
+
+	"github.com/txpool/core/types"
+)
+
+// txList is a nonce-indexed set of transactions belonging to a single
+// account, used for both the executable (pending) and non-executable
+// (queued) views the legacy pool keeps per account.
+type txList struct {
+	txs map[uint64]*types.Transaction
+}
+
+// newTxList returns a new, empty txList.
+func newTxList() *txList {
+	return &txList{
+		txs: make(map[uint64]*types.Transaction),
+	}
+}
+
+// Get returns the transaction occupying the given nonce, or nil if absent.
+func (l *txList) Get(nonce uint64) *types.Transaction {
+	return l.txs[nonce]
+}
+
+// Put inserts a transaction, replacing whatever currently sits at its nonce.
+func (l *txList) Put(tx *types.Transaction) {
+	l.txs[tx.Nonce()] = tx
+}
+
+// Remove deletes the transaction occupying the given nonce, if any.
+func (l *txList) Remove(nonce uint64) {
+	delete(l.txs, nonce)
+}
+
+// Len returns the number of transactions tracked by the list.
+func (l *txList) Len() int {
+	return len(l.txs)
+}
+
+// Flatten returns the list's transactions ordered by ascending nonce.
+func (l *txList) Flatten() []*types.Transaction {
+	nonces := make([]uint64, 0, len(l.txs))
+	for nonce := range l.txs {
+		nonces = append(nonces, nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+
+	txs := make([]*types.Transaction, 0, len(nonces))
+	for _, nonce := range nonces {
+		txs = append(txs, l.txs[nonce])
+	}
+	return txs
+}
@@ -0,0 +1,131 @@
+package legacypool
+
+import (
+	"container/heap"
+	"math/big"
+
+	"github.com/txpool/core/types"
+)
+
+// priceHeap is a min-heap of transactions ordered by ascending gas price, the
+// backing store for txPricedList.
+type priceHeap []*types.Transaction
+
+func (h priceHeap) Len() int      { return len(h) }
+func (h priceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h priceHeap) Less(i, j int) bool {
+	return h[i].GasPrice().Cmp(h[j].GasPrice()) < 0
+}
+
+func (h *priceHeap) Push(x interface{}) {
+	*h = append(*h, x.(*types.Transaction))
+}
+
+func (h *priceHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	tx := old[n-1]
+	*h = old[:n-1]
+	return tx
+}
+
+// txPricedList tracks every transaction known to the pool ordered by gas
+// price, so that a full pool can cheaply evict its cheapest entries. Removals
+// aren't applied to the heap directly (that's O(n)); instead they're counted
+// as stale and reconciled against the pool's all lookup, with a full rebuild
+// once stales pile up.
+type txPricedList struct {
+	all    *txLookup
+	items  *priceHeap
+	stales int
+}
+
+// newTxPricedList creates a priced list that cross-checks against all to tell
+// stale heap entries from live ones.
+func newTxPricedList(all *txLookup) *txPricedList {
+	items := make(priceHeap, 0)
+	return &txPricedList{
+		all:   all,
+		items: &items,
+	}
+}
+
+// Put adds a transaction to the priced list.
+func (l *txPricedList) Put(tx *types.Transaction) {
+	heap.Push(l.items, tx)
+}
+
+// Removed notifies the priced list that a transaction has left the pool.
+// Once enough entries have gone stale it rebuilds the heap to bound its size.
+func (l *txPricedList) Removed() {
+	l.stales++
+	if l.stales <= len(*l.items)/4 {
+		return
+	}
+	l.reheap()
+}
+
+// reheap rebuilds the heap from the pool's current contents, dropping every
+// entry that's no longer resident.
+func (l *txPricedList) reheap() {
+	items := make(priceHeap, 0, l.all.Count())
+	for _, tx := range l.all.all {
+		items = append(items, tx)
+	}
+	l.items = &items
+	l.stales = 0
+	heap.Init(l.items)
+}
+
+// Underpriced reports whether tx is cheaper than the cheapest transaction
+// currently tracked by the pool, meaning it would be the first candidate for
+// eviction rather than worth making room for.
+func (l *txPricedList) Underpriced(tx *types.Transaction) bool {
+	for len(*l.items) > 0 {
+		cheapest := (*l.items)[0]
+		if l.all.Get(cheapest.Hash()) == nil {
+			heap.Pop(l.items)
+			l.stales--
+			continue
+		}
+		return tx.GasPrice().Cmp(cheapest.GasPrice()) < 0
+	}
+	return false
+}
+
+// Discard pops up to n of the cheapest transactions still resident in the
+// pool and returns them for removal, skipping any transaction isLocal reports
+// true for. The second return value is false if fewer than n transactions
+// could be found to evict (e.g. every remaining candidate is local), meaning
+// the caller did not free up the room it asked for.
+func (l *txPricedList) Discard(n int, isLocal func(tx *types.Transaction) bool) ([]*types.Transaction, bool) {
+	dropped := make([]*types.Transaction, 0, n)
+	var skipped []*types.Transaction
+
+	for len(dropped) < n && len(*l.items) > 0 {
+		tx := heap.Pop(l.items).(*types.Transaction)
+		if l.all.Get(tx.Hash()) == nil {
+			l.stales--
+			continue
+		}
+		if isLocal(tx) {
+			skipped = append(skipped, tx)
+			continue
+		}
+		dropped = append(dropped, tx)
+	}
+	for _, tx := range skipped {
+		heap.Push(l.items, tx)
+	}
+	return dropped, len(dropped) >= n
+}
+
+// priceBump reports whether tx's gas price exceeds old's by at least the
+// given percentage, the bar a replacement transaction must clear to take over
+// the same nonce.
+func priceBump(old, tx *types.Transaction, bump uint64) bool {
+	threshold := new(big.Int).Mul(old.GasPrice(), big.NewInt(int64(100+bump)))
+	scaled := new(big.Int).Mul(tx.GasPrice(), big.NewInt(100))
+	return scaled.Cmp(threshold) >= 0
+}
@@ -0,0 +1,77 @@
+package legacypool
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/txpool/common"
+	"github.com/txpool/core/types"
+)
+
+func TestTxPricedListDiscard(t *testing.T) {
+	all := newTxLookup()
+	priced := newTxPricedList(all)
+
+	var locals []common.Address
+	for i, price := range []int64{1, 2, 3} {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx := signedTx(t, types.HomesteadSigner{}, key, uint64(i), price)
+		all.Add(tx)
+		priced.Put(tx)
+		if price == 3 {
+			locals = append(locals, crypto.PubkeyToAddress(key.PublicKey))
+		}
+	}
+	isLocal := func(tx *types.Transaction) bool {
+		from, err := types.Sender(types.HomesteadSigner{}, tx)
+		if err != nil {
+			return false
+		}
+		for _, addr := range locals {
+			if addr == from {
+				return true
+			}
+		}
+		return false
+	}
+
+	dropped, ok := priced.Discard(2, isLocal)
+	if !ok {
+		t.Fatal("Discard(2, ...) should have found two evictable transactions")
+	}
+	if len(dropped) != 2 {
+		t.Fatalf("len(dropped) = %d, want 2", len(dropped))
+	}
+	for _, tx := range dropped {
+		if tx.GasPrice().Cmp(big.NewInt(3)) == 0 {
+			t.Fatal("the local transaction must not be evicted")
+		}
+	}
+}
+
+func TestTxPricedListDiscardOverflow(t *testing.T) {
+	all := newTxLookup()
+	priced := newTxPricedList(all)
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := signedTx(t, types.HomesteadSigner{}, key, 0, 1)
+	all.Add(tx)
+	priced.Put(tx)
+
+	allLocal := func(tx *types.Transaction) bool { return true }
+
+	dropped, ok := priced.Discard(1, allLocal)
+	if ok {
+		t.Fatal("Discard should report failure when every candidate is exempt")
+	}
+	if len(dropped) != 0 {
+		t.Fatalf("len(dropped) = %d, want 0", len(dropped))
+	}
+}
@@ -0,0 +1,53 @@
+package txpool
+
+import (
+	"github.com/txpool/common"
+	"github.com/txpool/core/types"
+)
+
+// SubPool defines the interface that a sub transaction pool must implement to
+// be plugged into the top-level TxPool router. Each SubPool is responsible for
+// a single transaction "kind" (e.g. legacy transactions, blob transactions);
+// the router dispatches incoming transactions to whichever SubPool claims them.
+type SubPool interface {
+	// Filter reports whether this SubPool is able to handle the given
+	// transaction. The router calls Filter on every registered SubPool, in
+	// order, until one of them claims the transaction.
+	Filter(tx *types.Transaction) bool
+
+	// Add inserts a transaction already claimed by Filter into the SubPool.
+	Add(tx *types.Transaction) error
+
+	// AddTxs inserts a batch of transactions already claimed by Filter into
+	// the SubPool, returning one error per transaction in the same order as
+	// txs. Implementations should warm their sender cache across the whole
+	// batch up front, rather than looping Add one transaction at a time.
+	AddTxs(txs []*types.Transaction) []error
+
+	// Pending returns the currently executable transactions held by the SubPool.
+	Pending() []*types.Transaction
+
+	// Get returns a transaction by hash, or nil if the SubPool doesn't have it.
+	Get(hash common.Hash) *types.Transaction
+
+	// Remove deletes a transaction from the SubPool, if present.
+	Remove(hash common.Hash)
+
+	// Include removes a transaction from the SubPool because it was
+	// committed to the chain, as opposed to dropped by pool policy: it does
+	// not count towards Drained, but Status will report TxStatusIncluded for
+	// it afterwards.
+	Include(hash common.Hash)
+
+	// Status returns the lifecycle status of a transaction, or TxStatusUnknown
+	// if the SubPool doesn't have it.
+	Status(hash common.Hash) TxStatus
+
+	// Drained returns and clears the transactions the SubPool has evicted
+	// since the last call, whether through an explicit Remove or an internal
+	// eviction policy, so the router can fan them out as DropTxsEvents.
+	Drained() []*types.Transaction
+
+	// Reset clears the SubPool, discarding all known transactions.
+	Reset()
+}
@@ -0,0 +1,232 @@
+// TxPool is the top-level router in front of one or more SubPool instances.
+// It owns no transactions itself: every transaction lives inside exactly one
+// SubPool, and TxPool.AddTx/GetTxs simply route to and aggregate across them.
+
+package txpool
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/txpool/common"
+	"github.com/txpool/core/types"
+)
+
+// define errors description for error.
+var (
+	ErrTxTypeNotSupported = errors.New("transaction type not supported by any subpool")
+)
+
+// coalesceInterval is how often the event loop flushes a pending batch of
+// accepted transactions and polls subpools for evictions, so fan-out cost is
+// amortized instead of paid per transaction.
+const coalesceInterval = 50 * time.Millisecond
+
+// TxPool gathers transactions from the network and local submissions and
+// routes each one to whichever registered SubPool claims it.
+type TxPool struct {
+	subpools []SubPool
+
+	txFeed   event.Feed
+	dropFeed event.Feed
+
+	addedCh chan *types.Transaction
+	quit    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// New creates a TxPool that dispatches across the given subpools, in order.
+func New(subpools ...SubPool) *TxPool {
+	pool := &TxPool{
+		subpools: subpools,
+		addedCh:  make(chan *types.Transaction, 4096),
+		quit:     make(chan struct{}),
+	}
+	pool.wg.Add(1)
+	go pool.eventLoop()
+
+	return pool
+}
+
+// Stop terminates the event loop goroutine, flushing any pending batch first.
+func (pool *TxPool) Stop() {
+	close(pool.quit)
+	pool.wg.Wait()
+}
+
+// eventLoop coalesces newly accepted transactions and subpool evictions onto
+// a short timer, so fan-out happens in batches rather than one at a time.
+func (pool *TxPool) eventLoop() {
+	defer pool.wg.Done()
+
+	ticker := time.NewTicker(coalesceInterval)
+	defer ticker.Stop()
+
+	var added []*types.Transaction
+	flushAdded := func() {
+		if len(added) == 0 {
+			return
+		}
+		pool.txFeed.Send(NewTxsEvent{Txs: added})
+		added = nil
+	}
+	flushDropped := func() {
+		var dropped []*types.Transaction
+		for _, subpool := range pool.subpools {
+			dropped = append(dropped, subpool.Drained()...)
+		}
+		if len(dropped) > 0 {
+			pool.dropFeed.Send(DropTxsEvent{Txs: dropped})
+		}
+	}
+
+	for {
+		select {
+		case tx := <-pool.addedCh:
+			added = append(added, tx)
+
+		case <-ticker.C:
+			flushAdded()
+			flushDropped()
+
+		case <-pool.quit:
+			flushAdded()
+			flushDropped()
+			return
+		}
+	}
+}
+
+// SubscribeNewTxsEvent registers a subscription for NewTxsEvent, fired in
+// batches as transactions are accepted into the pool.
+func (pool *TxPool) SubscribeNewTxsEvent(ch chan<- NewTxsEvent) event.Subscription {
+	return pool.txFeed.Subscribe(ch)
+}
+
+// SubscribeDropTxsEvent registers a subscription for DropTxsEvent, fired in
+// batches as transactions leave the pool via DelTxs or internal eviction.
+func (pool *TxPool) SubscribeDropTxsEvent(ch chan<- DropTxsEvent) event.Subscription {
+	return pool.dropFeed.Subscribe(ch)
+}
+
+// AddTx routes a transaction to the first SubPool that claims it via Filter.
+// If no SubPool accepts the transaction, ErrTxTypeNotSupported is returned.
+func (pool *TxPool) AddTx(tx *types.Transaction) error {
+	for _, subpool := range pool.subpools {
+		if !subpool.Filter(tx) {
+			continue
+		}
+		if err := subpool.Add(tx); err != nil {
+			return err
+		}
+		pool.addedCh <- tx
+		return nil
+	}
+	return ErrTxTypeNotSupported
+}
+
+// AddTxs routes a batch of transactions across the registered subpools,
+// returning one error per transaction in the same order as txs. Each
+// transaction is grouped with its claiming subpool so AddTxs is called once
+// per subpool, rather than once per transaction.
+func (pool *TxPool) AddTxs(txs []*types.Transaction) []error {
+	errs := make([]error, len(txs))
+	for i := range errs {
+		errs[i] = ErrTxTypeNotSupported
+	}
+
+	// remaining tracks which transactions haven't yet been claimed by a
+	// subpool, so each one is offered to at most one subpool's AddTxs.
+	remaining := make([]*types.Transaction, len(txs))
+	copy(remaining, txs)
+
+	for _, subpool := range pool.subpools {
+		var (
+			indexes []int
+			batch   []*types.Transaction
+		)
+		for i, tx := range remaining {
+			if tx == nil {
+				continue
+			}
+			if !subpool.Filter(tx) {
+				continue
+			}
+			indexes = append(indexes, i)
+			batch = append(batch, tx)
+			remaining[i] = nil
+		}
+		if len(batch) == 0 {
+			continue
+		}
+		for j, err := range subpool.AddTxs(batch) {
+			errs[indexes[j]] = err
+			if err == nil {
+				pool.addedCh <- batch[j]
+			}
+		}
+	}
+	return errs
+}
+
+// GetTxs gets the transactions which are pending across all subpools.
+func (pool *TxPool) GetTxs() []*types.Transaction {
+	txs := make([]*types.Transaction, 0)
+	for _, subpool := range pool.subpools {
+		txs = append(txs, subpool.Pending()...)
+	}
+	return txs
+}
+
+// Status returns the status of each of the given transaction hashes, querying
+// every registered subpool in turn.
+func (pool *TxPool) Status(hashes []common.Hash) []TxStatus {
+	status := make([]TxStatus, len(hashes))
+	for i, hash := range hashes {
+		for _, subpool := range pool.subpools {
+			if s := subpool.Status(hash); s != TxStatusUnknown {
+				status[i] = s
+				break
+			}
+		}
+	}
+	return status
+}
+
+// Get returns a transaction by hash, searching every subpool.
+func (pool *TxPool) Get(hash common.Hash) *types.Transaction {
+	for _, subpool := range pool.subpools {
+		if tx := subpool.Get(hash); tx != nil {
+			return tx
+		}
+	}
+	return nil
+}
+
+// DelTxs removes the given committed transactions from every subpool.
+// Once a block has been committed, the transactions it contains can be
+// removed; they're reported as TxStatusIncluded afterwards, and the ones the
+// pool held are announced together in a single DropTxsEvent.
+func (pool *TxPool) DelTxs(hashes []common.Hash) {
+	var removed []*types.Transaction
+	for _, hash := range hashes {
+		if tx := pool.Get(hash); tx != nil {
+			removed = append(removed, tx)
+		}
+		for _, subpool := range pool.subpools {
+			subpool.Include(hash)
+		}
+	}
+	if len(removed) > 0 {
+		pool.dropFeed.Send(DropTxsEvent{Txs: removed})
+	}
+}
+
+// Reset clears every registered subpool, discarding all known transactions.
+func (pool *TxPool) Reset() {
+	for _, subpool := range pool.subpools {
+		subpool.Reset()
+	}
+}
@@ -0,0 +1,198 @@
+package txpool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/txpool/common"
+	"github.com/txpool/core/types"
+)
+
+// fakeSubPool is a minimal SubPool used to exercise the router in isolation,
+// claiming transactions whose first data byte matches kind.
+type fakeSubPool struct {
+	kind    byte
+	txs     map[common.Hash]*types.Transaction
+	dropped []*types.Transaction // txs evicted via Remove, returned by the next Drained
+}
+
+func newFakeSubPool(kind byte) *fakeSubPool {
+	return &fakeSubPool{kind: kind, txs: make(map[common.Hash]*types.Transaction)}
+}
+
+func (p *fakeSubPool) Filter(tx *types.Transaction) bool {
+	data := tx.Data()
+	return len(data) > 0 && data[0] == p.kind
+}
+
+func (p *fakeSubPool) Add(tx *types.Transaction) error {
+	p.txs[tx.Hash()] = tx
+	return nil
+}
+
+func (p *fakeSubPool) AddTxs(txs []*types.Transaction) []error {
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		errs[i] = p.Add(tx)
+	}
+	return errs
+}
+
+func (p *fakeSubPool) Pending() []*types.Transaction {
+	txs := make([]*types.Transaction, 0, len(p.txs))
+	for _, tx := range p.txs {
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+func (p *fakeSubPool) Get(hash common.Hash) *types.Transaction {
+	return p.txs[hash]
+}
+
+func (p *fakeSubPool) Remove(hash common.Hash) {
+	if tx, ok := p.txs[hash]; ok {
+		p.dropped = append(p.dropped, tx)
+	}
+	delete(p.txs, hash)
+}
+
+func (p *fakeSubPool) Include(hash common.Hash) {
+	delete(p.txs, hash)
+}
+
+func (p *fakeSubPool) Status(hash common.Hash) TxStatus {
+	if _, ok := p.txs[hash]; ok {
+		return TxStatusPending
+	}
+	return TxStatusUnknown
+}
+
+func (p *fakeSubPool) Drained() []*types.Transaction {
+	dropped := p.dropped
+	p.dropped = nil
+	return dropped
+}
+
+func (p *fakeSubPool) Reset() { p.txs = make(map[common.Hash]*types.Transaction) }
+
+func fakeTx(nonce uint64, kind byte) *types.Transaction {
+	return types.NewTransaction(nonce, common.Address{}, nil, 0, nil, []byte{kind})
+}
+
+func TestTxPoolAddTxRoutesByFilter(t *testing.T) {
+	a, b := newFakeSubPool('a'), newFakeSubPool('b')
+	pool := New(a, b)
+	defer pool.Stop()
+
+	tx := fakeTx(0, 'b')
+	if err := pool.AddTx(tx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if a.Get(tx.Hash()) != nil {
+		t.Fatal("subpool a should not have claimed a 'b' transaction")
+	}
+	if b.Get(tx.Hash()) == nil {
+		t.Fatal("subpool b should have claimed the transaction")
+	}
+
+	unclaimed := fakeTx(0, 'z')
+	if err := pool.AddTx(unclaimed); err != ErrTxTypeNotSupported {
+		t.Fatalf("AddTx(unclaimed) = %v, want ErrTxTypeNotSupported", err)
+	}
+}
+
+func TestTxPoolAddTxsGroupsBySubPool(t *testing.T) {
+	a, b := newFakeSubPool('a'), newFakeSubPool('b')
+	pool := New(a, b)
+	defer pool.Stop()
+
+	txs := []*types.Transaction{
+		fakeTx(0, 'a'),
+		fakeTx(0, 'b'),
+		fakeTx(1, 'a'),
+		fakeTx(0, 'z'),
+	}
+	errs := pool.AddTxs(txs)
+	if len(errs) != len(txs) {
+		t.Fatalf("len(errs) = %d, want %d", len(errs), len(txs))
+	}
+	for i, want := range []error{nil, nil, nil, ErrTxTypeNotSupported} {
+		if errs[i] != want {
+			t.Fatalf("errs[%d] = %v, want %v", i, errs[i], want)
+		}
+	}
+	if len(a.txs) != 2 {
+		t.Fatalf("len(a.txs) = %d, want 2", len(a.txs))
+	}
+	if len(b.txs) != 1 {
+		t.Fatalf("len(b.txs) = %d, want 1", len(b.txs))
+	}
+}
+
+func TestTxPoolStatusAndDelTxs(t *testing.T) {
+	a := newFakeSubPool('a')
+	pool := New(a)
+	defer pool.Stop()
+
+	tx := fakeTx(0, 'a')
+	if err := pool.AddTx(tx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status := pool.Status([]common.Hash{tx.Hash()})[0]; status != TxStatusPending {
+		t.Fatalf("status = %v, want TxStatusPending", status)
+	}
+
+	pool.DelTxs([]common.Hash{tx.Hash()})
+	if a.Get(tx.Hash()) != nil {
+		t.Fatal("DelTxs should have removed the transaction from its subpool")
+	}
+}
+
+func TestTxPoolEventFeedsCoalesce(t *testing.T) {
+	a := newFakeSubPool('a')
+	pool := New(a)
+	defer pool.Stop()
+
+	newCh := make(chan NewTxsEvent, 1)
+	newSub := pool.SubscribeNewTxsEvent(newCh)
+	defer newSub.Unsubscribe()
+
+	dropCh := make(chan DropTxsEvent, 1)
+	dropSub := pool.SubscribeDropTxsEvent(dropCh)
+	defer dropSub.Unsubscribe()
+
+	tx0 := fakeTx(0, 'a')
+	tx1 := fakeTx(1, 'a')
+	if err := pool.AddTx(tx0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := pool.AddTx(tx1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var added []*types.Transaction
+	select {
+	case ev := <-newCh:
+		added = ev.Txs
+	case <-time.After(20 * coalesceInterval):
+		t.Fatal("timed out waiting for NewTxsEvent")
+	}
+	if len(added) != 2 {
+		t.Fatalf("NewTxsEvent carried %d txs, want both accepted txs batched together", len(added))
+	}
+
+	a.Remove(tx0.Hash())
+	a.Remove(tx1.Hash())
+
+	var dropped []*types.Transaction
+	select {
+	case ev := <-dropCh:
+		dropped = ev.Txs
+	case <-time.After(20 * coalesceInterval):
+		t.Fatal("timed out waiting for DropTxsEvent")
+	}
+	if len(dropped) != 2 {
+		t.Fatalf("DropTxsEvent carried %d txs, want both evicted txs batched together", len(dropped))
+	}
+}
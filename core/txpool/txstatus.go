@@ -0,0 +1,11 @@
+package txpool
+
+// TxStatus describes the lifecycle stage of a transaction known to the pool.
+type TxStatus uint
+
+const (
+	TxStatusUnknown  TxStatus = iota // the pool has never seen this hash
+	TxStatusQueued                   // known, but blocked behind a nonce gap
+	TxStatusPending                  // known and nonce-contiguous, ready to be mined
+	TxStatusIncluded                 // known to have been committed to the chain
+)